@@ -0,0 +1,32 @@
+package chain
+
+import (
+	"fmt"
+	"math/big"
+	"net/http"
+)
+
+// bitcoinFetcher sums UTXO amounts for an address via a bitcoind-compatible
+// node's listunspent call, the same approach the dcrdex BTC wallet uses to
+// derive a spendable balance.
+type bitcoinFetcher struct{}
+
+const satoshisPerBTC = 1e8
+
+func (bitcoinFetcher) FetchBalance(client *http.Client, endpoint, address string) (string, error) {
+	var utxos []struct {
+		Amount float64 `json:"amount"`
+	}
+	if err := callRPC(client, endpoint, "listunspent", []interface{}{0, 9999999, []string{address}}, &utxos); err != nil {
+		return "", fmt.Errorf("listunspent for %s: %w", address, err)
+	}
+
+	total := big.NewFloat(0)
+	for _, utxo := range utxos {
+		total.Add(total, big.NewFloat(utxo.Amount))
+	}
+	total.Mul(total, big.NewFloat(satoshisPerBTC))
+
+	satoshis, _ := total.Int(nil)
+	return satoshis.String(), nil
+}
@@ -0,0 +1,91 @@
+// Package chain extends wallet-bot's balance lookups to network families
+// beyond the original hand-rolled Ethereum and Cosmos fetchers in the main
+// package. Each supported Kind has a BalanceFetcher that returns the raw,
+// smallest-unit balance (satoshis, lamports, planck, attoFIL) for a wallet
+// address; the caller is responsible for converting that into a
+// human-readable amount using the chain's configured ConversionFactor.
+package chain
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// Kind identifies a network family. It mirrors the `kind` field of the
+// Blockchain config and is the dispatch key for getBalance.
+type Kind string
+
+const (
+	KindEthereum Kind = "ethereum"
+	KindCosmos   Kind = "cosmos"
+	KindBitcoin  Kind = "bitcoin"
+	KindSolana   Kind = "solana"
+	KindPolkadot Kind = "polkadot"
+	KindFilecoin Kind = "filecoin"
+)
+
+// BalanceFetcher fetches the raw, smallest-unit balance for a wallet
+// address from a chain's RPC endpoint.
+type BalanceFetcher interface {
+	FetchBalance(client *http.Client, endpoint, address string) (raw string, err error)
+}
+
+var fetchers = map[Kind]BalanceFetcher{
+	KindBitcoin:  bitcoinFetcher{},
+	KindSolana:   solanaFetcher{},
+	KindPolkadot: polkadotFetcher{},
+	KindFilecoin: filecoinFetcher{},
+}
+
+// FetcherFor returns the BalanceFetcher registered for kind, if any.
+// Ethereum and Cosmos are not registered here; they keep their existing
+// bespoke fetchers in the main package.
+func FetcherFor(kind Kind) (BalanceFetcher, bool) {
+	f, ok := fetchers[kind]
+	return f, ok
+}
+
+type rpcRequest struct {
+	Version string        `json:"jsonrpc"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+	ID      int           `json:"id"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// callRPC performs a generic JSON-RPC 2.0 call against endpoint and decodes
+// the `result` field into result.
+func callRPC(client *http.Client, endpoint, method string, params []interface{}, result interface{}) error {
+	payload := rpcRequest{Version: "2.0", Method: method, Params: params, ID: 1}
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshaling %s payload: %w", method, err)
+	}
+
+	resp, err := client.Post(endpoint, "application/json", bytes.NewBuffer(payloadBytes))
+	if err != nil {
+		return fmt.Errorf("calling %s: %w", method, err)
+	}
+	defer resp.Body.Close()
+
+	var envelope struct {
+		Result json.RawMessage `json:"result"`
+		Error  *rpcError       `json:"error,omitempty"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&envelope); err != nil {
+		return fmt.Errorf("decoding %s response: %w", method, err)
+	}
+	if envelope.Error != nil {
+		return fmt.Errorf("%s RPC error %d: %s", method, envelope.Error.Code, envelope.Error.Message)
+	}
+	if result == nil {
+		return nil
+	}
+	return json.Unmarshal(envelope.Result, result)
+}
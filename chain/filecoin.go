@@ -0,0 +1,18 @@
+package chain
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// filecoinFetcher queries a Lotus node's Filecoin.WalletBalance method,
+// which returns the wallet's balance in attoFIL.
+type filecoinFetcher struct{}
+
+func (filecoinFetcher) FetchBalance(client *http.Client, endpoint, address string) (string, error) {
+	var result string
+	if err := callRPC(client, endpoint, "Filecoin.WalletBalance", []interface{}{address}, &result); err != nil {
+		return "", fmt.Errorf("Filecoin.WalletBalance for %s: %w", address, err)
+	}
+	return result, nil
+}
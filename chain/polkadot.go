@@ -0,0 +1,22 @@
+package chain
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// polkadotFetcher queries a Substrate node's system_account state for an
+// address, returning the account's free balance in planck.
+type polkadotFetcher struct{}
+
+func (polkadotFetcher) FetchBalance(client *http.Client, endpoint, address string) (string, error) {
+	var result struct {
+		Data struct {
+			Free string `json:"free"`
+		} `json:"data"`
+	}
+	if err := callRPC(client, endpoint, "system_account", []interface{}{address}, &result); err != nil {
+		return "", fmt.Errorf("system_account for %s: %w", address, err)
+	}
+	return result.Data.Free, nil
+}
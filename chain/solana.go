@@ -0,0 +1,21 @@
+package chain
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// solanaFetcher queries a Solana RPC node's getBalance method, which
+// returns the account's balance in lamports.
+type solanaFetcher struct{}
+
+func (solanaFetcher) FetchBalance(client *http.Client, endpoint, address string) (string, error) {
+	var result struct {
+		Value uint64 `json:"value"`
+	}
+	if err := callRPC(client, endpoint, "getBalance", []interface{}{address}, &result); err != nil {
+		return "", fmt.Errorf("getBalance for %s: %w", address, err)
+	}
+	return strconv.FormatUint(result.Value, 10), nil
+}
@@ -1,389 +1,848 @@
 package main
 
 import (
-    "bytes"
-    "encoding/json"
-    "fmt"
-    "io/ioutil"
-    "log"
-    "math/big"
-    "net/http"
-    "os"
-    "strconv"
-    "strings"
-    "time"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"math/big"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/time/rate"
+
+	chainpkg "github.com/validaoxyz/wallet-bot/chain"
+	"github.com/validaoxyz/wallet-bot/health"
+	"github.com/validaoxyz/wallet-bot/metrics"
+	"github.com/validaoxyz/wallet-bot/notifier"
+	"github.com/validaoxyz/wallet-bot/price"
 )
 
 type BlockchainNetworks struct {
-    Networks[] Blockchain `json:"blockchainNetworks"`
+	Networks  []Blockchain            `json:"blockchainNetworks"`
+	Notifiers []notifier.TargetConfig `json:"notifiers"`
+	// MaxConcurrency bounds how many wallet balance checks run at once
+	// across all chains. Defaults to the number of configured chains.
+	MaxConcurrency int `json:"maxConcurrency,omitempty"`
 }
 
 type Blockchain struct {
-    Identifier string `json:"identifier"`
-    Kind string `json:"kind"`
-    Endpoint string `json:"endpoint"`
-    Wallets[] Wallet `json:"wallets"`
-    ConversionFactor float64 `json:"conversionFactor"`
-    FailureThreshold float64 `json:"failureThreshold"`
-    CurrencyUnit string `json:"currencyUnit"`
-    PriceSource string `json:"priceSource"`
+	Identifier       string                  `json:"identifier"`
+	Kind             string                  `json:"kind"`
+	Endpoints        []string                `json:"endpoints"`
+	Wallets          []Wallet                `json:"wallets"`
+	ConversionFactor float64                 `json:"conversionFactor"`
+	FailureThreshold float64                 `json:"failureThreshold"`
+	CurrencyUnit     string                  `json:"currencyUnit"`
+	PriceSource      string                  `json:"priceSource"`
+	Notifiers        []notifier.TargetConfig `json:"notifiers,omitempty"`
+	TLS              TLSConfig               `json:"tls,omitempty"`
+	BasicAuth        *BasicAuthConfig        `json:"basicAuth,omitempty"`
+	// UnhealthyAfter is how many consecutive failures a provider tolerates
+	// before the health manager marks it unhealthy and rotates past it.
+	// Defaults to 3 when unset.
+	UnhealthyAfter int `json:"unhealthyAfter,omitempty"`
+	// RateLimit caps requests per second against this chain's current
+	// endpoint. Defaults to 5 when unset.
+	RateLimit float64 `json:"rateLimit,omitempty"`
 }
 
 type Wallet struct {
-    WalletAddress string `json:"walletAddress"`
-    UseCase string `json:"useCase"`
-    MinBalance float64 `json:"minBalance"`
-    EndpointFailures int `json:"-"`
-    IsBelowThreshold bool `json:"-"`
+	WalletAddress string  `json:"walletAddress"`
+	UseCase       string  `json:"useCase"`
+	MinBalance    float64 `json:"minBalance"`
+	// MinBalanceUSD, if set, additionally alerts when the wallet's balance
+	// converted to USD falls below it. Takes priority over MinBalanceFiat.
+	MinBalanceUSD float64 `json:"minBalanceUSD,omitempty"`
+	// MinBalanceFiat is a currency-agnostic fiat threshold, for operators
+	// who budget in a currency other than USD.
+	MinBalanceFiat *FiatThreshold `json:"minBalanceFiat,omitempty"`
+}
+
+// FiatThreshold pairs a fiat amount with its currency code (e.g. "EUR").
+type FiatThreshold struct {
+	Amount   float64 `json:"amount"`
+	Currency string  `json:"currency"`
 }
 
 type AccountBalances struct {
-    BalanceDetails[] BalanceDetail `json:"balanceDetails"`
+	BalanceDetails []BalanceDetail `json:"balanceDetails"`
 }
 
 type BalanceDetail struct {
-    Currency string `json:"currency"`
-    Amount string `json:"amount"`
+	Currency string `json:"currency"`
+	Amount   string `json:"amount"`
 }
 
 type RemoteIP struct {
-    IPAddress string `json:"-"`
+	IPAddress string `json:"-"`
 }
 
 type EthRPCPayload struct {
-    Version string `json:"jsonrpc"`
-    Action string `json:"method"`
-    Params[] interface {}
-    `json:"params"`
-    RequestID int `json:"id"`
+	Version   string        `json:"jsonrpc"`
+	Action    string        `json:"method"`
+	Params    []interface{} `json:"params"`
+	RequestID int           `json:"id"`
 }
 
 type EthRPCResult struct {
-    ResponseID int `json:"id"`
-    Data string `json:"result"`
-    RPCError * RPCErrorDetail `json:"error,omitempty"`
+	ResponseID int             `json:"id"`
+	Data       string          `json:"result"`
+	RPCError   *RPCErrorDetail `json:"error,omitempty"`
 }
 
 type RPCErrorDetail struct {
-    ErrorCode int `json:"code"`
-    ErrorMessage string `json:"message"`
+	ErrorCode    int    `json:"code"`
+	ErrorMessage string `json:"message"`
 }
 
 const (
-    WebhookBalance = ""
-    WebhookRPC = ""
-    HoursPerDay = 24
+	HoursPerDay = 24
+
+	// MetricsListenAddr is the address the Prometheus /metrics and /healthz
+	// endpoints are served on.
+	MetricsListenAddr = ":9090"
 )
 
 var (
-    RPCErrorThreshold int = 0 BalanceCheckInterval float64 = 0.5
+	BalanceCheckInterval float64 = 0.5
+
+	// defaultRouter dispatches alerts for chains that don't declare their
+	// own notifier targets. defaultRouter and chainRouters are rebuilt on
+	// every config reload while watchChainHealth goroutines from earlier
+	// reloads may still be dispatching, so both are guarded by routersMu.
+	routersMu     sync.Mutex
+	defaultRouter *notifier.Router
+	// chainRouters holds a per-chain override, keyed by Blockchain.Identifier.
+	chainRouters = map[string]*notifier.Router{}
+
+	// healthManagers holds each chain's endpoint health manager, keyed by
+	// Blockchain.Identifier. Guarded by healthManagersMu since balance
+	// checks run concurrently across chains.
+	healthManagersMu sync.Mutex
+	healthManagers   = map[string]*health.Manager{}
+
+	// rateLimiters holds a token-bucket limiter per RPC endpoint, keyed by
+	// the endpoint URL, so concurrent wallet checks against the same
+	// endpoint don't overwhelm it.
+	rateLimitersMu sync.Mutex
+	rateLimiters   = map[string]*rate.Limiter{}
+
+	// walletStates holds the mutable runtime state (consecutive endpoint
+	// failures, whether we've already alerted) for each (chain, wallet)
+	// pair, protected by its own mutex rather than living on Wallet values
+	// that get copied on every range iteration.
+	walletStatesMu sync.Mutex
+	walletStates   = map[walletKey]*walletState{}
+
+	// priceSources holds each chain's price.Source, wrapped in a TTL cache
+	// and circuit breaker, keyed by Blockchain.Identifier. Built lazily so
+	// chains without a fiat threshold configured never make a price call.
+	priceSourcesMu sync.Mutex
+	priceSources   = map[string]price.Source{}
 )
 
+const (
+	priceCacheTTL               = 30 * time.Second
+	priceCircuitBreakerFails    = 3
+	priceCircuitBreakerCooldown = 2 * time.Minute
+)
+
+type walletKey struct {
+	chain  string
+	wallet string
+}
+
+type walletState struct {
+	endpointFailures int
+	belowThreshold   bool
+}
+
+func stateFor(chain Blockchain, wallet Wallet) *walletState {
+	key := walletKey{chain: chain.Identifier, wallet: wallet.WalletAddress}
+
+	walletStatesMu.Lock()
+	defer walletStatesMu.Unlock()
+	state, ok := walletStates[key]
+	if !ok {
+		state = &walletState{}
+		walletStates[key] = state
+	}
+	return state
+}
+
 func main() {
-    blockchains: = loadBlockchainConfig()
-
-    for {
-        updateConfigurations( & blockchains)
-
-        var encounteredIssues bool
-        for idx, chain: = range blockchains.Networks {
-            RPCErrorThreshold = int(chain.FailureThreshold / BalanceCheckInterval)
-            for i, wallet: = range chain.Wallets {
-                balances, err: = getBalance(chain, wallet)
-                if err != nil {
-                    blockchains.Networks[idx].Wallets[i].EndpointFailures++
-                        encounteredIssues = true
-                    checkRPCHealth(chain, wallet)
-                    continue
-                }
-
-                balance, err, isValid: = analyzeBalance(balances, chain)
-                if isValid {
-                    handleBalanceThreshold(chain, wallet, balance)
-                } else {
-                    blockchains.Networks[idx].Wallets[i].EndpointFailures++
-                        encounteredIssues = true
-                    checkRPCHealth(chain, wallet)
-                }
-
-                // Reset RPC down count after successful balance fetch
-                if blockchains.Networks[idx].Wallets[i].EndpointFailures > 0 {
-                    blockchains.Networks[idx].Wallets[i].EndpointFailures = 0
-                }
-            }
-        }
-
-        logCompletion(encounteredIssues)
-        time.Sleep(time.Duration(60 * BalanceCheckInterval) * time.Minute)
-    }
+	blockchains := loadBlockchainConfig()
+	buildRouters(blockchains)
+	buildHealthManagers(blockchains)
+
+	go serveMetrics()
+
+	for {
+		updateConfigurations(&blockchains)
+		buildRouters(blockchains)
+		buildHealthManagers(blockchains)
+
+		encounteredIssues := runBalanceChecks(blockchains)
+
+		logCompletion(encounteredIssues)
+		time.Sleep(time.Duration(60*BalanceCheckInterval) * time.Minute)
+	}
+}
+
+// runBalanceChecks fans every configured wallet's balance check out across
+// a bounded worker pool, rate limited per endpoint, and reports whether any
+// check hit an issue.
+func runBalanceChecks(blockchains BlockchainNetworks) bool {
+	maxConcurrency := blockchains.MaxConcurrency
+	if maxConcurrency <= 0 {
+		maxConcurrency = len(blockchains.Networks)
+	}
+	if maxConcurrency <= 0 {
+		maxConcurrency = 1
+	}
+
+	g, ctx := errgroup.WithContext(context.Background())
+	g.SetLimit(maxConcurrency)
+
+	var issuesMu sync.Mutex
+	var encounteredIssues bool
+
+	for _, chain := range blockchains.Networks {
+		chain := chain
+		rpcErrorThreshold := int(chain.FailureThreshold / BalanceCheckInterval)
+		for _, wallet := range chain.Wallets {
+			wallet := wallet
+			g.Go(func() error {
+				if issue := checkWalletBalance(ctx, chain, wallet, rpcErrorThreshold); issue {
+					issuesMu.Lock()
+					encounteredIssues = true
+					issuesMu.Unlock()
+				}
+				return nil
+			})
+		}
+	}
+
+	g.Wait()
+	return encounteredIssues
+}
+
+// checkWalletBalance fetches and evaluates a single wallet's balance,
+// reporting whether it encountered an issue (RPC failure or invalid
+// response).
+func checkWalletBalance(ctx context.Context, chain Blockchain, wallet Wallet, rpcErrorThreshold int) bool {
+	state := stateFor(chain, wallet)
+
+	if limiter := rateLimiterFor(healthManagerFor(chain).Current(), chain.RateLimit); limiter != nil {
+		if err := limiter.Wait(ctx); err != nil {
+			return true
+		}
+	}
+
+	start := time.Now()
+	balances, err := getBalance(chain, wallet)
+	metrics.ObserveBalanceCheckDuration(chain.Identifier, wallet.WalletAddress, wallet.UseCase, time.Since(start).Seconds())
+
+	var issue bool
+	if err != nil {
+		issue = true
+	} else {
+		balance, _, isValid := analyzeBalance(balances, chain)
+		if isValid {
+			metrics.SetWalletBalance(chain.Identifier, wallet.WalletAddress, wallet.UseCase, balance)
+			handleBalanceThreshold(chain, wallet, balance, state)
+		} else {
+			issue = true
+		}
+	}
+
+	walletStatesMu.Lock()
+	if issue {
+		state.endpointFailures++
+	} else if state.endpointFailures > 0 {
+		state.endpointFailures = 0
+	}
+	failures := state.endpointFailures
+	walletStatesMu.Unlock()
+
+	if issue {
+		checkRPCHealth(chain, wallet, failures, rpcErrorThreshold)
+	}
+	return issue
+}
+
+// rateLimiterFor returns the shared limiter for endpoint, creating one on
+// first use. ratePerSecond <= 0 falls back to a conservative default.
+func rateLimiterFor(endpoint string, ratePerSecond float64) *rate.Limiter {
+	if endpoint == "" {
+		return nil
+	}
+	if ratePerSecond <= 0 {
+		ratePerSecond = 5
+	}
+
+	rateLimitersMu.Lock()
+	defer rateLimitersMu.Unlock()
+	limiter, ok := rateLimiters[endpoint]
+	if !ok {
+		limiter = rate.NewLimiter(rate.Limit(ratePerSecond), 1)
+		rateLimiters[endpoint] = limiter
+	}
+	return limiter
+}
+
+// serveMetrics starts the HTTP server exposing the Prometheus /metrics
+// endpoint and a /healthz JSON endpoint, blocking until it exits. It is
+// intended to be run in its own goroutine for the lifetime of the process.
+func serveMetrics() {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metrics.Handler())
+	mux.HandleFunc("/healthz", handleHealthz)
+
+	logEvent(fmt.Sprintf("Serving metrics on %s", MetricsListenAddr))
+	if err := http.ListenAndServe(MetricsListenAddr, mux); err != nil {
+		logEvent(fmt.Sprintf("[ERROR] Metrics server stopped: %v", err))
+	}
+}
+
+// healthzResponse reports the aggregate health manager status for every
+// configured chain, plus the worst of them as the overall status.
+type healthzResponse struct {
+	Status string            `json:"status"`
+	Chains map[string]string `json:"chains"`
+}
+
+// handleHealthz surfaces each chain's health.Manager.Aggregate() status.
+// It reports 503 when any chain is fully down (every provider unhealthy),
+// so the endpoint doubles as a readiness probe rather than a pure liveness
+// stub.
+func handleHealthz(w http.ResponseWriter, r *http.Request) {
+	healthManagersMu.Lock()
+	chains := make(map[string]string, len(healthManagers))
+	overall := health.StatusUp
+	for id, manager := range healthManagers {
+		status := manager.Aggregate()
+		chains[id] = string(status)
+		switch {
+		case status == health.StatusDown:
+			overall = health.StatusDown
+		case status == health.StatusDegraded && overall != health.StatusDown:
+			overall = health.StatusDegraded
+		}
+	}
+	healthManagersMu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	if overall == health.StatusDown {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	json.NewEncoder(w).Encode(healthzResponse{Status: string(overall), Chains: chains})
+}
+
+// buildRouters rebuilds the default and per-chain notifier routers from the
+// current configuration. It is called on startup and on every config
+// reload so that notifier targets can be changed without a restart.
+func buildRouters(blockchains BlockchainNetworks) {
+	routersMu.Lock()
+	defer routersMu.Unlock()
+
+	router, err := notifier.NewRouter(blockchains.Notifiers)
+	if err != nil {
+		logEvent(fmt.Sprintf("[ERROR] Failed to build default notifier router: %v", err))
+	} else {
+		defaultRouter = router
+	}
+
+	for _, chain := range blockchains.Networks {
+		if len(chain.Notifiers) == 0 {
+			delete(chainRouters, chain.Identifier)
+			continue
+		}
+		router, err := notifier.NewRouter(chain.Notifiers)
+		if err != nil {
+			logEvent(fmt.Sprintf("[ERROR] Failed to build notifier router for %s: %v", chain.Identifier, err))
+			continue
+		}
+		chainRouters[chain.Identifier] = router
+	}
+}
+
+// buildHealthManagers ensures every configured chain has a health manager,
+// creating one the first time a chain is seen. Existing managers are left
+// alone across config reloads so their accumulated provider state isn't
+// discarded; only newly added or removed chains change the map.
+func buildHealthManagers(blockchains BlockchainNetworks) {
+	healthManagersMu.Lock()
+	defer healthManagersMu.Unlock()
+
+	seen := make(map[string]bool, len(blockchains.Networks))
+	for _, chain := range blockchains.Networks {
+		seen[chain.Identifier] = true
+		if _, ok := healthManagers[chain.Identifier]; ok {
+			continue
+		}
+		manager := health.NewManager(chain.Identifier, chain.Endpoints, chain.UnhealthyAfter)
+		healthManagers[chain.Identifier] = manager
+		go watchChainHealth(chain.Identifier, manager)
+	}
+	for id := range healthManagers {
+		if !seen[id] {
+			delete(healthManagers, id)
+		}
+	}
+}
+
+// healthManagerFor returns the health manager for chain, creating one on
+// the fly if buildHealthManagers hasn't run for it yet. Safe to call
+// concurrently from the per-wallet check goroutines.
+func healthManagerFor(chain Blockchain) *health.Manager {
+	healthManagersMu.Lock()
+	defer healthManagersMu.Unlock()
+
+	if manager, ok := healthManagers[chain.Identifier]; ok {
+		return manager
+	}
+	manager := health.NewManager(chain.Identifier, chain.Endpoints, chain.UnhealthyAfter)
+	healthManagers[chain.Identifier] = manager
+	go watchChainHealth(chain.Identifier, manager)
+	return manager
 }
+
+// watchChainHealth pages operators only once every provider for a chain
+// has failed, and sends the matching resolve once a chain that was fully
+// down has a provider recover. A status change that never touches Down
+// (e.g. Up<->Degraded as individual providers blip) is not a chain-level
+// outage either way, so it's left silent.
+func watchChainHealth(chainID string, manager *health.Manager) {
+	prevStatus := health.StatusUp
+	for status := range manager.Subscribe() {
+		if status.Status != health.StatusDown && prevStatus != health.StatusDown {
+			prevStatus = status.Status
+			continue
+		}
+
+		chain := Blockchain{Identifier: chainID}
+		alertStatus := notifier.StatusTrigger
+		message := fmt.Sprintf("[ALERT] All RPC providers for %s are down", chainID)
+		if status.Status != health.StatusDown {
+			alertStatus = notifier.StatusResolve
+			message = fmt.Sprintf("[INFO] RPC providers for %s have recovered (status: %s)", chainID, status.Status)
+		}
+		logEvent(message)
+		dispatchAlert(chain, notifier.Alert{
+			Severity: notifier.SeverityRPC,
+			Status:   alertStatus,
+			Chain:    chainID,
+			Message:  message,
+			DedupKey: fmt.Sprintf("%s:all-providers-down", chainID),
+		})
+		prevStatus = status.Status
+	}
+}
+
+// routerForChain returns the chain's own notifier router if it declared
+// one, otherwise the default router built from the top-level config.
+func routerForChain(chain Blockchain) *notifier.Router {
+	routersMu.Lock()
+	defer routersMu.Unlock()
+
+	if router, ok := chainRouters[chain.Identifier]; ok {
+		return router
+	}
+	return defaultRouter
+}
+
+func dispatchAlert(chain Blockchain, alert notifier.Alert) {
+	router := routerForChain(chain)
+	if router == nil {
+		return
+	}
+	if err := router.Dispatch(alert); err != nil {
+		logEvent(fmt.Sprintf("[ERROR] Failed to dispatch %s alert: %v", alert.Severity, err))
+	}
+}
+
 func loadBlockchainConfig() BlockchainNetworks {
-    configFile, err: = os.Open("blockchainConfig.json")
-    if err != nil {
-        logEvent(fmt.Sprintf("[ERROR] Could not open blockchainConfig.json; error: `%v`", err))
-    }
-    defer configFile.Close()
-
-    byteValue, err: = ioutil.ReadAll(configFile)
-    if err != nil {
-        logEvent(fmt.Sprintf("[ERROR] Failed to read content from blockchainConfig.json; error: `%v`", err))
-    }
-    var blockchains BlockchainNetworks
-    json.Unmarshal(byteValue, & blockchains)
-
-    for idx, chain: = range blockchains.Networks {
-        blockchains.Networks[idx].Endpoint = determineEndpoint(chain)
-    }
-    return blockchains
-}
-func updateConfigurations(currentBlockchains * BlockchainNetworks) {
-    updatedBlockchains: = loadBlockchainConfig()
-    for idx,
-    chain: = range updatedBlockchains.Networks {
-        for i, _: = range chain.Wallets {
-            updatedBlockchains.Networks[idx].Wallets[i].IsBelowThreshold = currentBlockchains.Networks[idx].Wallets[i].IsBelowThreshold
-            updatedBlockchains.Networks[idx].Wallets[i].EndpointFailures = currentBlockchains.Networks[idx].Wallets[i].EndpointFailures
-        }
-    } * currentBlockchains = updatedBlockchains
-}
-
-func fetchEthBalance(blockchain Blockchain, wallet Wallet)( * AccountBalances, error) {
-    rpcPayload: = EthRPCPayload {
-        Version: "2.0",
-        Action: "eth_getBalance",
-        Params: [] interface {} {
-            wallet.WalletAddress, "latest"
-        },
-        RequestID: 1,
-    }
-    payloadBytes,
-    err: = json.Marshal(rpcPayload)
-    if err != nil {
-        return nil, err
-    }
-    response,
-    err: = http.Post(blockchain.Endpoint, "application/json", bytes.NewBuffer(payloadBytes))
-    if err != nil {
-        return nil, err
-    }
-    defer response.Body.Close()
-
-    body,
-    err: = ioutil.ReadAll(response.Body)
-    if err != nil {
-        return nil, err
-    }
-
-    var ethResult EthRPCResult
-    if err: = json.Unmarshal(body, & ethResult);err != nil {
-        return nil, err
-    }
-
-    hexStr: = strings.TrimPrefix(ethResult.Data, "0x")
-    dec: = new(big.Int)
-    dec.SetString(hexStr, 16)
-
-    return &AccountBalances {
-        BalanceDetails: [] BalanceDetail {
-            {
-                Currency: blockchain.CurrencyUnit,
-                Amount: dec.String()
-            }
-        }
-    },
-    nil
-}
-
-func fetchCosmosBalance(blockchain Blockchain, wallet Wallet)( * AccountBalances, error) {
-    url: = fmt.Sprintf("%v/cosmos/bank/v1beta1/balances/%v", determineEndpoint(blockchain), wallet.WalletAddress)
-    client: = http.Client {
-        Timeout: 40 * time.Second
-    }
-    resp,
-    err: = client.Get(url)
-    if err != nil {
-        return nil, err
-    }
-    defer resp.Body.Close()
-
-    body,
-    err: = ioutil.ReadAll(resp.Body)
-    if err != nil {
-        return nil, err
-    }
-
-    // Correctly defining the structure to match the real API response.
-    var apiResponse struct {
-        Balances[] struct {
-            Denom string `json:"denom"`
-            Amount string `json:"amount"`
-        }
-        `json:"balances"`
-        Pagination struct {
-            NextKey string `json:"next_key"`
-            Total string `json:"total"`
-        }
-        `json:"pagination"`
-    }
-
-    if err: = json.Unmarshal(body, & apiResponse);err != nil {
-        logEvent(fmt.Sprintf("Raw API Response: %s", string(body))) // For debugging
-        return nil, err
-    }
-
-    // Convert the API response to the AccountBalances structure expected by the rest of the program.
-    var balances AccountBalances
-    for _,
-    balance: = range apiResponse.Balances {
-        balances.BalanceDetails = append(balances.BalanceDetails, BalanceDetail {
-            Currency: balance.Denom,
-            Amount: balance.Amount,
-        })
-    }
-
-        return &balances, nil
-}
-
-
-func analyzeBalance(balances * AccountBalances, blockchain Blockchain)(float64, error, bool) {
-    var resultGood bool
-    var balance float64 = 0
-    var err error
-
-    for _, detail: = range balances.BalanceDetails {
-        if detail.Currency == blockchain.CurrencyUnit {
-            balance, err = strconv.ParseFloat(detail.Amount, 64)
-            if err != nil {
-                return 0, fmt.Errorf("error converting string `%v` to float64; error: `%v`", detail.Amount, err), resultGood
-            }
-            resultGood = true
-            break
-        }
-    }
-
-    if !resultGood {
-        return 0, fmt.Errorf("currency unit mismatch in response"), resultGood
-    }
-
-    return balance, nil, resultGood
-}
-
-
-func determineEndpoint(chain Blockchain) string {
-    if chain.Endpoint == "" {
-        return fmt.Sprintf("https://rest.cosmos.directory/%v", chain.Identifier)
-    }
-    return chain.Endpoint
-}
-
-
-
-func sendWebhookNotification(webhookURL, message string) {
-    payload: = map[string] string {
-        "text": message
-    }
-    payloadBytes,
-    err: = json.Marshal(payload)
-    if err != nil {
-        logEvent(fmt.Sprintf("Error marshaling Webhook payload: %v", err))
-        return
-    }
-
-    resp,
-    err: = http.Post(webhookURL, "application/json", bytes.NewBuffer(payloadBytes))
-    if err != nil {
-        logEvent(fmt.Sprintf("Failed to send Webhook notification: %v", err))
-        return
-    }
-    defer resp.Body.Close()
-
-    if resp.StatusCode == http.StatusOK {
-        logEvent("Webhook notification sent successfully")
-    } else {
-        logEvent(fmt.Sprintf("Failed to send Webhook notification, status code: %d", resp.StatusCode))
-    }
-}
-
-func handleLowBalanceAlert(chain Blockchain, wallet Wallet, balance float64) {
-    message: = fmt.Sprintf("ðŸ’” [ALERT] Wallet %s in %s is low on funds. Balance: %.f %v", wallet.UseCase, chain.Identifier, balance, chain.CurrencyUnit)
-    logEvent(message)
-    if !wallet.IsBelowThreshold {
-        sendWebhookNotification(WebhookBalance, message)
-        wallet.IsBelowThreshold = true
-    }
-}
-
-func notifyBalanceChange(status string, balance float64, blockchain Blockchain, wallet Wallet) {
-    var message string
-    switch status {
-        case "low":
-            message = fmt.Sprintf("[ALERT] Wallet %s in %s is low on funds. Current balance: %.f %v", wallet.UseCase, blockchain.Identifier, balance, blockchain.CurrencyUnit)
-        case "restored":
-            message = fmt.Sprintf("[INFO] Wallet %s in %s has been replenished. New balance: %.f %v", wallet.UseCase, blockchain.Identifier, balance, blockchain.CurrencyUnit)
-    }
-    logEvent(message)
-    sendWebhookNotification(WebhookBalance, message)
-}
-
-func notifyRPCIssue(status string, blockchain Blockchain, wallet Wallet) {
-    var message string
-    if status == "issue" {
-        message = fmt.Sprintf("[ALERT] RPC endpoint for %s has issues. Endpoint: %s, Wallet: %s", blockchain.Identifier, blockchain.Endpoint, wallet.WalletAddress)
-    }
-    logEvent(message)
-    sendWebhookNotification(WebhookRPC, message)
-}
-
-
-func handleBalanceThreshold(chain Blockchain, wallet Wallet, balance float64) {
-    balanceThresholdCrossed: = balance < wallet.MinBalance
-    if balanceThresholdCrossed && !wallet.IsBelowThreshold {
-        message: = fmt.Sprintf("ðŸ’” [ALERT] Wallet %s in %s is low on funds. Balance: %.2f %v", wallet.UseCase, chain.Identifier, balance, chain.CurrencyUnit)
-        logEvent(message)
-        sendWebhookNotification(WebhookBalance, message)
-        wallet.IsBelowThreshold = true // Mark as notified
-    } else if !balanceThresholdCrossed && wallet.IsBelowThreshold {
-        message: = fmt.Sprintf("ðŸ’š [INFO] Wallet %s in %s has been replenished. New balance: %.2f %v", wallet.UseCase, chain.Identifier, balance, chain.CurrencyUnit)
-        logEvent(message)
-        sendWebhookNotification(WebhookBalance, message)
-        wallet.IsBelowThreshold = false // Reset notification flag
-    } else if balanceThresholdCrossed {
-        // Log but don't notify for subsequent low balance checks until balance is restored
-        logEvent(fmt.Sprintf("ðŸ’” Wallet %s in %s remains low on funds. Balance: %.2f %v", wallet.UseCase, chain.Identifier, balance, chain.CurrencyUnit))
-    }
-}
-
-func getBalance(chain Blockchain, wallet Wallet)( * AccountBalances, error) {
-    var balances * AccountBalances
-    var err error
-    switch chain.Kind {
-        case "ethereum":
-            balances, err = fetchEthBalance(chain, wallet)
-        case "cosmos":
-            balances, err = fetchCosmosBalance(chain, wallet)
-        default:
-            err = fmt.Errorf("unsupported blockchain kind: %s", chain.Kind)
-    }
-    return balances, err
-}
-
-func checkRPCHealth(chain Blockchain, wallet Wallet) {
-    if wallet.EndpointFailures >= RPCErrorThreshold {
-        message: = fmt.Sprintf("[ALERT] RPC endpoint for %s has issues. Endpoint: %s, Wallet: %s, Failures: %d", chain.Identifier, chain.Endpoint, wallet.WalletAddress, wallet.EndpointFailures)
-        logEvent(message)
-        sendWebhookNotification(WebhookRPC, message)
-    }
+	configFile, err := os.Open("blockchainConfig.json")
+	if err != nil {
+		logEvent(fmt.Sprintf("[ERROR] Could not open blockchainConfig.json; error: `%v`", err))
+	}
+	defer configFile.Close()
+
+	byteValue, err := ioutil.ReadAll(configFile)
+	if err != nil {
+		logEvent(fmt.Sprintf("[ERROR] Failed to read content from blockchainConfig.json; error: `%v`", err))
+	}
+	var blockchains BlockchainNetworks
+	json.Unmarshal(byteValue, &blockchains)
+
+	for idx, chain := range blockchains.Networks {
+		blockchains.Networks[idx].Endpoints = resolveEndpoints(chain)
+	}
+	return blockchains
 }
 
+func updateConfigurations(currentBlockchains *BlockchainNetworks) {
+	// Wallet runtime state (failure counts, alert state) now lives in
+	// walletStates, keyed by (chain, wallet) rather than on the config
+	// structs themselves, so it survives a config reload unattended.
+	*currentBlockchains = loadBlockchainConfig()
+}
+
+func fetchEthBalance(blockchain Blockchain, wallet Wallet, endpoint string) (*AccountBalances, error) {
+	client, err := httpClientFor(blockchain)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcPayload := EthRPCPayload{
+		Version:   "2.0",
+		Action:    "eth_getBalance",
+		Params:    []interface{}{wallet.WalletAddress, "latest"},
+		RequestID: 1,
+	}
+	payloadBytes, err := json.Marshal(rpcPayload)
+	if err != nil {
+		return nil, err
+	}
+	response, err := client.Post(endpoint, "application/json", bytes.NewBuffer(payloadBytes))
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var ethResult EthRPCResult
+	if err := json.Unmarshal(body, &ethResult); err != nil {
+		return nil, err
+	}
+
+	hexStr := strings.TrimPrefix(ethResult.Data, "0x")
+	dec := new(big.Int)
+	dec.SetString(hexStr, 16)
+
+	return &AccountBalances{
+		BalanceDetails: []BalanceDetail{
+			{
+				Currency: blockchain.CurrencyUnit,
+				Amount:   dec.String(),
+			},
+		},
+	}, nil
+}
+
+func fetchCosmosBalance(blockchain Blockchain, wallet Wallet, endpoint string) (*AccountBalances, error) {
+	url := fmt.Sprintf("%v/cosmos/bank/v1beta1/balances/%v", endpoint, wallet.WalletAddress)
+	client, err := httpClientFor(blockchain)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	// Correctly defining the structure to match the real API response.
+	var apiResponse struct {
+		Balances []struct {
+			Denom  string `json:"denom"`
+			Amount string `json:"amount"`
+		} `json:"balances"`
+		Pagination struct {
+			NextKey string `json:"next_key"`
+			Total   string `json:"total"`
+		} `json:"pagination"`
+	}
+
+	if err := json.Unmarshal(body, &apiResponse); err != nil {
+		logEvent(fmt.Sprintf("Raw API Response: %s", string(body))) // For debugging
+		return nil, err
+	}
+
+	// Convert the API response to the AccountBalances structure expected by the rest of the program.
+	var balances AccountBalances
+	for _, balance := range apiResponse.Balances {
+		balances.BalanceDetails = append(balances.BalanceDetails, BalanceDetail{
+			Currency: balance.Denom,
+			Amount:   balance.Amount,
+		})
+	}
+
+	return &balances, nil
+}
+
+func analyzeBalance(balances *AccountBalances, blockchain Blockchain) (float64, error, bool) {
+	var resultGood bool
+	var balance float64 = 0
+	var err error
+
+	for _, detail := range balances.BalanceDetails {
+		if detail.Currency == blockchain.CurrencyUnit {
+			balance, err = strconv.ParseFloat(detail.Amount, 64)
+			if err != nil {
+				return 0, fmt.Errorf("error converting string `%v` to float64; error: `%v`", detail.Amount, err), resultGood
+			}
+			resultGood = true
+			break
+		}
+	}
+
+	if !resultGood {
+		return 0, fmt.Errorf("currency unit mismatch in response"), resultGood
+	}
+
+	// ConversionFactor normalizes a chain's smallest-unit balance (wei,
+	// satoshis, lamports, planck, attoFIL, ...) into a human-readable
+	// amount. Chains that already report human-readable amounts (e.g.
+	// Cosmos) simply leave it unset.
+	if blockchain.ConversionFactor > 0 {
+		balance = balance / blockchain.ConversionFactor
+	}
+
+	return balance, nil, resultGood
+}
+
+// resolveEndpoints fills in the default public Cosmos REST endpoint when a
+// Cosmos chain doesn't configure any of its own.
+func resolveEndpoints(chain Blockchain) []string {
+	if len(chain.Endpoints) == 0 && chain.Kind == "cosmos" {
+		return []string{fmt.Sprintf("https://rest.cosmos.directory/%v", chain.Identifier)}
+	}
+	return chain.Endpoints
+}
+
+func balanceDedupKey(chain Blockchain, wallet Wallet) string {
+	return fmt.Sprintf("%s:%s:low-balance", chain.Identifier, wallet.WalletAddress)
+}
+
+// priceSourceFor returns chain's price source, built and cached the first
+// time it's needed. Chains that don't configure PriceSource never build
+// one, so they never make a price API call.
+func priceSourceFor(chain Blockchain) price.Source {
+	if chain.PriceSource == "" {
+		return nil
+	}
+
+	priceSourcesMu.Lock()
+	defer priceSourcesMu.Unlock()
+	if source, ok := priceSources[chain.Identifier]; ok {
+		return source
+	}
+
+	source, err := price.Build(chain.PriceSource)
+	if err != nil {
+		logEvent(fmt.Sprintf("[ERROR] Failed to build price source for %s: %v", chain.Identifier, err))
+		return nil
+	}
+
+	wrapped := price.WithCache(price.WithCircuitBreaker(source, priceCircuitBreakerFails, priceCircuitBreakerCooldown), priceCacheTTL)
+	priceSources[chain.Identifier] = wrapped
+	return wrapped
+}
+
+// fiatThreshold resolves a wallet's configured fiat threshold, if any.
+// MinBalanceUSD takes priority over the currency-agnostic MinBalanceFiat.
+func fiatThreshold(wallet Wallet) (amount float64, currency string, ok bool) {
+	if wallet.MinBalanceUSD > 0 {
+		return wallet.MinBalanceUSD, "USD", true
+	}
+	if wallet.MinBalanceFiat != nil && wallet.MinBalanceFiat.Amount > 0 {
+		return wallet.MinBalanceFiat.Amount, wallet.MinBalanceFiat.Currency, true
+	}
+	return 0, "", false
+}
+
+// fiatBalance converts a wallet's native balance into the given fiat
+// currency using chain's configured price source. ok is false whenever no
+// threshold is configured or the price lookup fails (including when the
+// source's circuit breaker is open), in which case the caller should fall
+// back to evaluating the native-unit threshold alone rather than risk a
+// spurious alert from a price-source outage.
+func fiatBalance(chain Blockchain, wallet Wallet, balance float64) (value, thresholdAmount float64, currency string, ok bool) {
+	thresholdAmount, currency, ok = fiatThreshold(wallet)
+	if !ok {
+		return 0, 0, "", false
+	}
+
+	source := priceSourceFor(chain)
+	if source == nil {
+		return 0, 0, "", false
+	}
+
+	unitPrice, err := source.Price(context.Background(), chain.CurrencyUnit, currency)
+	if err != nil {
+		logEvent(fmt.Sprintf("[WARN] Price lookup failed for %s, evaluating native-unit threshold only: %v", chain.Identifier, err))
+		return 0, 0, "", false
+	}
+
+	return balance * unitPrice, thresholdAmount, currency, true
+}
+
+func handleBalanceThreshold(chain Blockchain, wallet Wallet, balance float64, state *walletState) {
+	walletStatesMu.Lock()
+	wasBelowThreshold := state.belowThreshold
+	walletStatesMu.Unlock()
+
+	fiatValue, fiatThresholdAmount, fiatCurrency, fiatOK := fiatBalance(chain, wallet, balance)
+
+	nativeCrossed := balance < wallet.MinBalance
+	fiatCrossed := fiatOK && fiatValue < fiatThresholdAmount
+	balanceThresholdCrossed := nativeCrossed || fiatCrossed
+
+	balanceDescription := fmt.Sprintf("%.2f %v", balance, chain.CurrencyUnit)
+	if fiatOK {
+		balanceDescription = fmt.Sprintf("%.2f %v ≈ $%.2f %v, threshold $%.2f %v", balance, chain.CurrencyUnit, fiatValue, fiatCurrency, fiatThresholdAmount, fiatCurrency)
+	}
+
+	if balanceThresholdCrossed && !wasBelowThreshold {
+		message := fmt.Sprintf("\U0001F494 [ALERT] Wallet %s in %s is low on funds. Balance: %s", wallet.UseCase, chain.Identifier, balanceDescription)
+		logEvent(message)
+		dispatchAlert(chain, notifier.Alert{
+			Severity: notifier.SeverityBalance,
+			Status:   notifier.StatusTrigger,
+			Chain:    chain.Identifier,
+			Wallet:   wallet.WalletAddress,
+			UseCase:  wallet.UseCase,
+			Message:  message,
+			DedupKey: balanceDedupKey(chain, wallet),
+		})
+		walletStatesMu.Lock()
+		state.belowThreshold = true // Mark as notified
+		walletStatesMu.Unlock()
+		metrics.SetBelowThreshold(chain.Identifier, wallet.WalletAddress, wallet.UseCase, true)
+	} else if !balanceThresholdCrossed && wasBelowThreshold {
+		message := fmt.Sprintf("\U0001F49A [INFO] Wallet %s in %s has been replenished. New balance: %s", wallet.UseCase, chain.Identifier, balanceDescription)
+		logEvent(message)
+		dispatchAlert(chain, notifier.Alert{
+			Severity: notifier.SeverityBalance,
+			Status:   notifier.StatusResolve,
+			Chain:    chain.Identifier,
+			Wallet:   wallet.WalletAddress,
+			UseCase:  wallet.UseCase,
+			Message:  message,
+			DedupKey: balanceDedupKey(chain, wallet),
+		})
+		walletStatesMu.Lock()
+		state.belowThreshold = false // Reset notification flag
+		walletStatesMu.Unlock()
+		metrics.SetBelowThreshold(chain.Identifier, wallet.WalletAddress, wallet.UseCase, false)
+	} else if balanceThresholdCrossed {
+		// Log but don't notify for subsequent low balance checks until balance is restored
+		logEvent(fmt.Sprintf("\U0001F494 Wallet %s in %s remains low on funds. Balance: %s", wallet.UseCase, chain.Identifier, balanceDescription))
+		metrics.SetBelowThreshold(chain.Identifier, wallet.WalletAddress, wallet.UseCase, true)
+	} else {
+		metrics.SetBelowThreshold(chain.Identifier, wallet.WalletAddress, wallet.UseCase, false)
+	}
+}
+
+func getBalance(chain Blockchain, wallet Wallet) (*AccountBalances, error) {
+	manager := healthManagerFor(chain)
+	endpoint := manager.Current()
+
+	var balances *AccountBalances
+	var err error
+	start := time.Now()
+	switch chain.Kind {
+	case "ethereum":
+		balances, err = fetchEthBalance(chain, wallet, endpoint)
+	case "cosmos":
+		balances, err = fetchCosmosBalance(chain, wallet, endpoint)
+	case string(chainpkg.KindBitcoin), string(chainpkg.KindSolana), string(chainpkg.KindPolkadot), string(chainpkg.KindFilecoin):
+		balances, err = fetchChainBalance(chain, wallet, endpoint)
+	default:
+		err = fmt.Errorf("unsupported blockchain kind: %s", chain.Kind)
+	}
+
+	if err != nil {
+		manager.RecordFailure(endpoint, err)
+		metrics.IncRPCFailure(chain.Identifier, wallet.WalletAddress, wallet.UseCase)
+	} else {
+		manager.RecordSuccess(endpoint, time.Since(start))
+	}
+	return balances, err
+}
+
+// fetchChainBalance dispatches to the chain package's BalanceFetcher for
+// network families that don't need a bespoke fetcher of their own.
+func fetchChainBalance(chain Blockchain, wallet Wallet, endpoint string) (*AccountBalances, error) {
+	fetcher, ok := chainpkg.FetcherFor(chainpkg.Kind(chain.Kind))
+	if !ok {
+		return nil, fmt.Errorf("unsupported blockchain kind: %s", chain.Kind)
+	}
+
+	client, err := httpClientFor(chain)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := fetcher.FetchBalance(client, endpoint, wallet.WalletAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AccountBalances{
+		BalanceDetails: []BalanceDetail{
+			{
+				Currency: chain.CurrencyUnit,
+				Amount:   raw,
+			},
+		},
+	}, nil
+}
+
+// checkRPCHealth only pages when every provider for chain has failed over
+// (health.StatusDown); watchChainHealth already sends that chain-level page,
+// so this is a per-wallet backstop for it rather than an independent alert.
+// A wallet crossing its own failure threshold while the manager has simply
+// rotated to another healthy provider (StatusUp/StatusDegraded) is expected
+// and shouldn't page.
+func checkRPCHealth(chain Blockchain, wallet Wallet, failures, rpcErrorThreshold int) {
+	if failures < rpcErrorThreshold {
+		return
+	}
+	if healthManagerFor(chain).Aggregate() != health.StatusDown {
+		return
+	}
+
+	message := fmt.Sprintf("[ALERT] RPC endpoint for %s has issues. Endpoint: %s, Wallet: %s, Failures: %d", chain.Identifier, healthManagerFor(chain).Current(), wallet.WalletAddress, failures)
+	logEvent(message)
+	dispatchAlert(chain, notifier.Alert{
+		Severity: notifier.SeverityRPC,
+		Status:   notifier.StatusTrigger,
+		Chain:    chain.Identifier,
+		Wallet:   wallet.WalletAddress,
+		UseCase:  wallet.UseCase,
+		Message:  message,
+		DedupKey: fmt.Sprintf("%s:%s:rpc-issue", chain.Identifier, wallet.WalletAddress),
+	})
+}
 
 func logCompletion(issues bool) {
-    if !issues {
-        logEvent("Run completed successfully with all networks checked.")
-    } else {
-        logEvent("Run completed with issues in one or more networks.")
-    }
+	if !issues {
+		logEvent("Run completed successfully with all networks checked.")
+	} else {
+		logEvent("Run completed with issues in one or more networks.")
+	}
 }
 
 func logEvent(msg string) {
-    log.Printf("%v\n", msg)
+	log.Printf("%v\n", msg)
 }
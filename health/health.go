@@ -0,0 +1,195 @@
+// Package health tracks the liveness of a chain's RPC providers and
+// rotates between them on failure, so a single flaky endpoint doesn't page
+// an operator while other providers for the same chain are still serving
+// requests.
+package health
+
+import (
+	"sync"
+	"time"
+)
+
+// Status is the aggregate health of all of a chain's providers.
+type Status string
+
+const (
+	StatusUp       Status = "up"       // at least one provider healthy, none failing
+	StatusDegraded Status = "degraded" // at least one provider unhealthy, but not all
+	StatusDown     Status = "down"     // every provider is unhealthy
+)
+
+// BlockchainHealthStatus is published on a Manager's subscription channel
+// whenever the aggregate status of a chain's providers changes.
+type BlockchainHealthStatus struct {
+	Chain  string
+	Status Status
+}
+
+// providerState is the per-provider bookkeeping a Manager maintains.
+type providerState struct {
+	url              string
+	consecutiveFails int
+	successes        int
+	failures         int
+	lastError        error
+	lastLatency      time.Duration
+	healthy          bool
+}
+
+// Manager tracks per-provider success/failure counts and latency for one
+// chain's list of RPC endpoints, rotating to the next provider once the
+// current one has failed UnhealthyAfter times in a row.
+type Manager struct {
+	mu             sync.Mutex
+	chain          string
+	providers      []*providerState
+	current        int
+	unhealthyAfter int
+	lastStatus     Status
+	statusCh       chan BlockchainHealthStatus
+}
+
+// NewManager creates a Manager for chain's list of endpoints. unhealthyAfter
+// is the number of consecutive failures before a provider is marked
+// unhealthy and rotated past.
+func NewManager(chain string, endpoints []string, unhealthyAfter int) *Manager {
+	if unhealthyAfter <= 0 {
+		unhealthyAfter = 3
+	}
+	providers := make([]*providerState, len(endpoints))
+	for i, url := range endpoints {
+		providers[i] = &providerState{url: url, healthy: true}
+	}
+	return &Manager{
+		chain:          chain,
+		providers:      providers,
+		unhealthyAfter: unhealthyAfter,
+		lastStatus:     StatusUp,
+		statusCh:       make(chan BlockchainHealthStatus, 1),
+	}
+}
+
+// Current returns the endpoint that should be used for the next request:
+// the current provider if healthy, otherwise the next healthy one found by
+// rotating through the list.
+func (m *Manager) Current() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(m.providers) == 0 {
+		return ""
+	}
+	if !m.providers[m.current].healthy {
+		m.rotateLocked()
+	}
+	return m.providers[m.current].url
+}
+
+// RecordSuccess resets the named provider's consecutive-failure count and
+// marks it healthy.
+func (m *Manager) RecordSuccess(url string, latency time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	p := m.providerLocked(url)
+	if p == nil {
+		return
+	}
+	p.successes++
+	p.consecutiveFails = 0
+	p.lastLatency = latency
+	p.lastError = nil
+	p.healthy = true
+
+	m.publishLocked()
+}
+
+// RecordFailure records a failed request against the named provider,
+// marking it unhealthy and rotating to the next provider once
+// unhealthyAfter consecutive failures have been seen.
+func (m *Manager) RecordFailure(url string, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	p := m.providerLocked(url)
+	if p == nil {
+		return
+	}
+	p.failures++
+	p.consecutiveFails++
+	p.lastError = err
+	if p.consecutiveFails >= m.unhealthyAfter {
+		p.healthy = false
+		m.rotateLocked()
+	}
+
+	m.publishLocked()
+}
+
+// Subscribe returns the channel BlockchainHealthStatus updates are
+// published on. It is buffered by 1; a slow consumer only sees the latest
+// status rather than blocking the health manager.
+func (m *Manager) Subscribe() <-chan BlockchainHealthStatus {
+	return m.statusCh
+}
+
+// Aggregate returns the current aggregate status without blocking on the
+// subscription channel.
+func (m *Manager) Aggregate() Status {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.aggregateLocked()
+}
+
+func (m *Manager) providerLocked(url string) *providerState {
+	for _, p := range m.providers {
+		if p.url == url {
+			return p
+		}
+	}
+	return nil
+}
+
+func (m *Manager) rotateLocked() {
+	for i := 1; i <= len(m.providers); i++ {
+		next := (m.current + i) % len(m.providers)
+		if m.providers[next].healthy {
+			m.current = next
+			return
+		}
+	}
+	// Every provider is unhealthy; advance anyway so we keep retrying
+	// round-robin rather than hammering the same one.
+	m.current = (m.current + 1) % len(m.providers)
+}
+
+func (m *Manager) aggregateLocked() Status {
+	healthy := 0
+	for _, p := range m.providers {
+		if p.healthy {
+			healthy++
+		}
+	}
+	switch {
+	case healthy == len(m.providers):
+		return StatusUp
+	case healthy == 0:
+		return StatusDown
+	default:
+		return StatusDegraded
+	}
+}
+
+func (m *Manager) publishLocked() {
+	status := m.aggregateLocked()
+	if status == m.lastStatus {
+		return
+	}
+	m.lastStatus = status
+
+	select {
+	case <-m.statusCh: // drop the stale pending update, if any
+	default:
+	}
+	m.statusCh <- BlockchainHealthStatus{Chain: m.chain, Status: status}
+}
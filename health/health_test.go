@@ -0,0 +1,91 @@
+package health
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestManager_CurrentDefaultsToFirstEndpoint(t *testing.T) {
+	m := NewManager("eth", []string{"a", "b"}, 2)
+	if got := m.Current(); got != "a" {
+		t.Fatalf("Current() = %q, want %q", got, "a")
+	}
+}
+
+func TestManager_RotatesAfterUnhealthyAfterFailures(t *testing.T) {
+	m := NewManager("eth", []string{"a", "b"}, 2)
+
+	m.RecordFailure("a", errors.New("boom"))
+	if got := m.Current(); got != "a" {
+		t.Fatalf("Current() after 1 failure = %q, want %q (not yet unhealthy)", got, "a")
+	}
+
+	m.RecordFailure("a", errors.New("boom"))
+	if got := m.Current(); got != "b" {
+		t.Fatalf("Current() after %d failures = %q, want %q", 2, got, "b")
+	}
+}
+
+func TestManager_RecordSuccessResetsFailureCount(t *testing.T) {
+	m := NewManager("eth", []string{"a", "b"}, 2)
+
+	m.RecordFailure("a", errors.New("boom"))
+	m.RecordSuccess("a", time.Millisecond)
+	m.RecordFailure("a", errors.New("boom"))
+	if got := m.Current(); got != "a" {
+		t.Fatalf("Current() = %q, want %q (failure count should have reset on success)", got, "a")
+	}
+}
+
+func TestManager_AggregateStatus(t *testing.T) {
+	m := NewManager("eth", []string{"a", "b"}, 1)
+
+	if got := m.Aggregate(); got != StatusUp {
+		t.Fatalf("Aggregate() before any failures = %q, want %q", got, StatusUp)
+	}
+
+	m.RecordFailure("a", errors.New("boom"))
+	if got := m.Aggregate(); got != StatusDegraded {
+		t.Fatalf("Aggregate() with one provider down = %q, want %q", got, StatusDegraded)
+	}
+
+	m.RecordFailure("b", errors.New("boom"))
+	if got := m.Aggregate(); got != StatusDown {
+		t.Fatalf("Aggregate() with every provider down = %q, want %q", got, StatusDown)
+	}
+
+	m.RecordSuccess("a", time.Millisecond)
+	if got := m.Aggregate(); got != StatusDegraded {
+		t.Fatalf("Aggregate() after one provider recovers = %q, want %q", got, StatusDegraded)
+	}
+}
+
+func TestManager_PublishesOnStatusChange(t *testing.T) {
+	m := NewManager("eth", []string{"a"}, 1)
+	ch := m.Subscribe()
+
+	m.RecordFailure("a", errors.New("boom"))
+
+	select {
+	case got := <-ch:
+		if got.Chain != "eth" || got.Status != StatusDown {
+			t.Fatalf("published %+v, want {Chain: eth, Status: down}", got)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for status change to publish")
+	}
+}
+
+func TestManager_DoesNotPublishWhenStatusUnchanged(t *testing.T) {
+	m := NewManager("eth", []string{"a", "b"}, 1)
+	ch := m.Subscribe()
+
+	m.RecordSuccess("a", time.Millisecond)
+
+	select {
+	case got := <-ch:
+		t.Fatalf("unexpected publish %+v for a no-op status change", got)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
@@ -0,0 +1,66 @@
+// Package metrics exposes the wallet-bot's internal state as Prometheus
+// metrics so operators can alert and build dashboards off of it instead of
+// relying solely on webhook notifications.
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var labels = []string{"chain", "wallet", "use_case"}
+
+var (
+	walletBalance = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "wallet_balance",
+		Help: "Latest observed balance for a wallet, in its chain's native currency unit.",
+	}, labels)
+
+	rpcFailuresTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "wallet_rpc_failures_total",
+		Help: "Total number of failed RPC balance lookups, per wallet.",
+	}, labels)
+
+	belowThreshold = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "wallet_below_threshold",
+		Help: "1 if a wallet's balance is currently below its configured minimum, 0 otherwise.",
+	}, labels)
+
+	balanceCheckDurationSeconds = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name: "balance_check_duration_seconds",
+		Help: "Time taken to fetch and evaluate a wallet's balance.",
+	}, labels)
+)
+
+// Handler returns the HTTP handler to serve the /metrics endpoint.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// SetWalletBalance records the latest observed balance for a wallet.
+func SetWalletBalance(chain, wallet, useCase string, balance float64) {
+	walletBalance.WithLabelValues(chain, wallet, useCase).Set(balance)
+}
+
+// IncRPCFailure increments the RPC failure counter for a wallet.
+func IncRPCFailure(chain, wallet, useCase string) {
+	rpcFailuresTotal.WithLabelValues(chain, wallet, useCase).Inc()
+}
+
+// SetBelowThreshold records whether a wallet is currently below its
+// configured minimum balance.
+func SetBelowThreshold(chain, wallet, useCase string, below bool) {
+	value := 0.0
+	if below {
+		value = 1.0
+	}
+	belowThreshold.WithLabelValues(chain, wallet, useCase).Set(value)
+}
+
+// ObserveBalanceCheckDuration records how long a balance check took.
+func ObserveBalanceCheckDuration(chain, wallet, useCase string, seconds float64) {
+	balanceCheckDurationSeconds.WithLabelValues(chain, wallet, useCase).Observe(seconds)
+}
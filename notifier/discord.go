@@ -0,0 +1,56 @@
+package notifier
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// DiscordNotifier posts to a Discord webhook using the embeds API.
+type DiscordNotifier struct {
+	webhookURL string
+}
+
+func NewDiscordNotifier(webhookURL string) *DiscordNotifier {
+	return &DiscordNotifier{webhookURL: webhookURL}
+}
+
+func (n *DiscordNotifier) Notify(alert Alert) error {
+	return n.post(alert)
+}
+
+func (n *DiscordNotifier) Resolve(alert Alert) error {
+	return n.post(alert)
+}
+
+func (n *DiscordNotifier) post(alert Alert) error {
+	color := 0xE01E5A // red, for a triggering alert
+	if alert.Status == StatusResolve {
+		color = 0x2EB67D // green, for a resolved alert
+	}
+
+	payload := map[string]interface{}{
+		"embeds": []map[string]interface{}{
+			{
+				"description": alert.Message,
+				"color":       color,
+			},
+		},
+	}
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshaling Discord payload: %w", err)
+	}
+
+	resp, err := httpClient.Post(n.webhookURL, "application/json", bytes.NewBuffer(payloadBytes))
+	if err != nil {
+		return fmt.Errorf("sending Discord notification: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("Discord notification failed, status code: %d", resp.StatusCode)
+	}
+	return nil
+}
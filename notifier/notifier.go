@@ -0,0 +1,134 @@
+// Package notifier abstracts the destinations wallet-bot can alert to.
+// Each backend implements Notifier; the caller is responsible for routing
+// alerts to the right set of targets based on severity.
+package notifier
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// httpClient is shared by every backend's outbound notification request.
+// dispatchAlert runs synchronously inside each per-wallet worker-pool
+// goroutine, so a hanging notifier endpoint without a timeout would stall
+// that whole tick rather than just failing one notification.
+var httpClient = &http.Client{Timeout: 10 * time.Second}
+
+// Severity classifies an alert so it can be routed to the right targets.
+type Severity string
+
+const (
+	SeverityBalance Severity = "balance"
+	SeverityRPC     Severity = "rpc"
+)
+
+// Alert is a backend-agnostic description of something worth notifying
+// about. Status distinguishes a newly firing condition ("trigger") from one
+// that has cleared ("resolve"), which backends that support incident
+// lifecycles (PagerDuty) use to auto-close.
+type Alert struct {
+	Severity Severity
+	Status   Status
+	Chain    string
+	Wallet   string
+	UseCase  string
+	Message  string
+	// DedupKey identifies the underlying condition across trigger/resolve
+	// pairs, e.g. "<chain>:<wallet>:low-balance".
+	DedupKey string
+}
+
+type Status string
+
+const (
+	StatusTrigger Status = "trigger"
+	StatusResolve Status = "resolve"
+)
+
+// Notifier delivers alerts to a single destination. Backends that don't
+// model incident lifecycles (e.g. plain webhooks) deliver a resolve alert's
+// message the same way as a triggering one.
+type Notifier interface {
+	Notify(Alert) error
+	Resolve(Alert) error
+}
+
+// TargetConfig describes one configured notifier destination, as found in
+// a chain's (or the top-level) `notifiers` config list.
+type TargetConfig struct {
+	Type       string     `json:"type"`
+	URL        string     `json:"url"`
+	Token      string     `json:"token,omitempty"`
+	Channel    string     `json:"channel,omitempty"`
+	Severities []Severity `json:"severities"`
+}
+
+// Build constructs the Notifier described by cfg.
+func Build(cfg TargetConfig) (Notifier, error) {
+	switch cfg.Type {
+	case "slack":
+		return NewSlackNotifier(cfg.URL), nil
+	case "discord":
+		return NewDiscordNotifier(cfg.URL), nil
+	case "telegram":
+		return NewTelegramNotifier(cfg.Token, cfg.Channel), nil
+	case "pagerduty":
+		return NewPagerDutyNotifier(cfg.Token), nil
+	case "webhook", "":
+		return NewWebhookNotifier(cfg.URL), nil
+	default:
+		return nil, fmt.Errorf("unsupported notifier type: %q", cfg.Type)
+	}
+}
+
+// Router dispatches alerts to every target subscribed to the alert's
+// severity.
+type Router struct {
+	targets []routedTarget
+}
+
+type routedTarget struct {
+	notifier   Notifier
+	severities map[Severity]bool
+}
+
+// NewRouter builds a Router from the given target configs. If any target
+// fails to build, it returns that error and no Router.
+func NewRouter(configs []TargetConfig) (*Router, error) {
+	r := &Router{}
+	for _, cfg := range configs {
+		n, err := Build(cfg)
+		if err != nil {
+			return nil, err
+		}
+		sevs := make(map[Severity]bool, len(cfg.Severities))
+		for _, s := range cfg.Severities {
+			sevs[s] = true
+		}
+		r.targets = append(r.targets, routedTarget{notifier: n, severities: sevs})
+	}
+	return r, nil
+}
+
+// Dispatch sends the alert to every target routed for its severity. It
+// collects and returns the first error encountered, continuing to notify
+// the remaining targets.
+func (r *Router) Dispatch(alert Alert) error {
+	var firstErr error
+	for _, target := range r.targets {
+		if len(target.severities) > 0 && !target.severities[alert.Severity] {
+			continue
+		}
+		var err error
+		if alert.Status == StatusResolve {
+			err = target.notifier.Resolve(alert)
+		} else {
+			err = target.notifier.Notify(alert)
+		}
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
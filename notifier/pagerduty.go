@@ -0,0 +1,64 @@
+package notifier
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+const pagerDutyEventsURL = "https://events.pagerduty.com/v2/enqueue"
+
+// PagerDutyNotifier sends PagerDuty Events v2 payloads. It uses the
+// alert's DedupKey so that a later Resolve call auto-closes the incident
+// opened by the matching Notify call, rather than requiring manual ack.
+type PagerDutyNotifier struct {
+	routingKey string
+}
+
+func NewPagerDutyNotifier(routingKey string) *PagerDutyNotifier {
+	return &PagerDutyNotifier{routingKey: routingKey}
+}
+
+func (n *PagerDutyNotifier) Notify(alert Alert) error {
+	return n.send(alert, "trigger")
+}
+
+func (n *PagerDutyNotifier) Resolve(alert Alert) error {
+	return n.send(alert, "resolve")
+}
+
+func (n *PagerDutyNotifier) send(alert Alert, eventAction string) error {
+	payload := map[string]interface{}{
+		"routing_key":  n.routingKey,
+		"event_action": eventAction,
+		"dedup_key":    alert.DedupKey,
+		"payload": map[string]interface{}{
+			"summary":  alert.Message,
+			"source":   alert.Chain,
+			"severity": pagerDutySeverity(alert.Severity),
+		},
+	}
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshaling PagerDuty payload: %w", err)
+	}
+
+	resp, err := httpClient.Post(pagerDutyEventsURL, "application/json", bytes.NewBuffer(payloadBytes))
+	if err != nil {
+		return fmt.Errorf("sending PagerDuty event: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusAccepted {
+		return fmt.Errorf("PagerDuty event rejected, status code: %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func pagerDutySeverity(s Severity) string {
+	if s == SeverityRPC {
+		return "error"
+	}
+	return "warning"
+}
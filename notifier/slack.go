@@ -0,0 +1,55 @@
+package notifier
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// SlackNotifier posts to a Slack incoming webhook using the Block Kit API
+// so alerts render with a bit more structure than plain text.
+type SlackNotifier struct {
+	webhookURL string
+}
+
+func NewSlackNotifier(webhookURL string) *SlackNotifier {
+	return &SlackNotifier{webhookURL: webhookURL}
+}
+
+func (n *SlackNotifier) Notify(alert Alert) error {
+	return n.post(alert.Message)
+}
+
+func (n *SlackNotifier) Resolve(alert Alert) error {
+	return n.post(alert.Message)
+}
+
+func (n *SlackNotifier) post(message string) error {
+	payload := map[string]interface{}{
+		"blocks": []map[string]interface{}{
+			{
+				"type": "section",
+				"text": map[string]string{
+					"type": "mrkdwn",
+					"text": message,
+				},
+			},
+		},
+	}
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshaling Slack payload: %w", err)
+	}
+
+	resp, err := httpClient.Post(n.webhookURL, "application/json", bytes.NewBuffer(payloadBytes))
+	if err != nil {
+		return fmt.Errorf("sending Slack notification: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Slack notification failed, status code: %d", resp.StatusCode)
+	}
+	return nil
+}
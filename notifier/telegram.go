@@ -0,0 +1,50 @@
+package notifier
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// TelegramNotifier sends messages through the Telegram Bot API to a
+// configured chat.
+type TelegramNotifier struct {
+	botToken string
+	chatID   string
+}
+
+func NewTelegramNotifier(botToken, chatID string) *TelegramNotifier {
+	return &TelegramNotifier{botToken: botToken, chatID: chatID}
+}
+
+func (n *TelegramNotifier) Notify(alert Alert) error {
+	return n.post(alert.Message)
+}
+
+func (n *TelegramNotifier) Resolve(alert Alert) error {
+	return n.post(alert.Message)
+}
+
+func (n *TelegramNotifier) post(message string) error {
+	payload := map[string]string{
+		"chat_id": n.chatID,
+		"text":    message,
+	}
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshaling Telegram payload: %w", err)
+	}
+
+	url := fmt.Sprintf("https://api.telegram.org/bot%s/sendMessage", n.botToken)
+	resp, err := httpClient.Post(url, "application/json", bytes.NewBuffer(payloadBytes))
+	if err != nil {
+		return fmt.Errorf("sending Telegram notification: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Telegram notification failed, status code: %d", resp.StatusCode)
+	}
+	return nil
+}
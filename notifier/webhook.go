@@ -0,0 +1,44 @@
+package notifier
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// WebhookNotifier posts a plain `{"text": "..."}` payload, matching the
+// bot's original generic webhook behavior.
+type WebhookNotifier struct {
+	url string
+}
+
+func NewWebhookNotifier(url string) *WebhookNotifier {
+	return &WebhookNotifier{url: url}
+}
+
+func (n *WebhookNotifier) Notify(alert Alert) error {
+	return n.post(alert.Message)
+}
+
+func (n *WebhookNotifier) Resolve(alert Alert) error {
+	return n.post(alert.Message)
+}
+
+func (n *WebhookNotifier) post(message string) error {
+	payloadBytes, err := json.Marshal(map[string]string{"text": message})
+	if err != nil {
+		return fmt.Errorf("marshaling webhook payload: %w", err)
+	}
+
+	resp, err := httpClient.Post(n.url, "application/json", bytes.NewBuffer(payloadBytes))
+	if err != nil {
+		return fmt.Errorf("sending webhook notification: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("webhook notification failed, status code: %d", resp.StatusCode)
+	}
+	return nil
+}
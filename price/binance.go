@@ -0,0 +1,57 @@
+package price
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// BinanceSource queries Binance's public ticker price endpoint.
+type BinanceSource struct {
+	client *http.Client
+}
+
+func NewBinanceSource() *BinanceSource {
+	return &BinanceSource{client: http.DefaultClient}
+}
+
+func (s *BinanceSource) Price(ctx context.Context, currencyUnit, fiatCurrency string) (float64, error) {
+	symbol := strings.ToUpper(currencyUnit) + strings.ToUpper(binanceQuoteAsset(fiatCurrency))
+	url := fmt.Sprintf("https://api.binance.com/api/v3/ticker/price?symbol=%s", symbol)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("querying Binance: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Price string `json:"price"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return 0, fmt.Errorf("decoding Binance response: %w", err)
+	}
+
+	price, err := strconv.ParseFloat(result.Price, 64)
+	if err != nil {
+		return 0, fmt.Errorf("parsing Binance price %q: %w", result.Price, err)
+	}
+	return price, nil
+}
+
+// binanceQuoteAsset maps a fiat currency code to the asset Binance actually
+// quotes against, since Binance has no directly USD-quoted pairs. USDT
+// tracks USD closely enough for a balance threshold check.
+func binanceQuoteAsset(fiatCurrency string) string {
+	if strings.ToUpper(fiatCurrency) == "USD" {
+		return "USDT"
+	}
+	return fiatCurrency
+}
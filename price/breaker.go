@@ -0,0 +1,51 @@
+package price
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// CircuitBreakerSource wraps a Source so that a run of upstream failures
+// stops retrying it for cooldown, returning an error immediately instead.
+// This keeps a flaky price API from generating a balance check failure (and
+// a spurious alert) on every single tick.
+type CircuitBreakerSource struct {
+	underlying Source
+	threshold  int
+	cooldown   time.Duration
+
+	mu               sync.Mutex
+	consecutiveFails int
+	openedAt         time.Time
+}
+
+// WithCircuitBreaker wraps source, opening the circuit after threshold
+// consecutive failures for the given cooldown period.
+func WithCircuitBreaker(source Source, threshold int, cooldown time.Duration) *CircuitBreakerSource {
+	return &CircuitBreakerSource{underlying: source, threshold: threshold, cooldown: cooldown}
+}
+
+func (b *CircuitBreakerSource) Price(ctx context.Context, currencyUnit, fiatCurrency string) (float64, error) {
+	b.mu.Lock()
+	open := b.consecutiveFails >= b.threshold && time.Since(b.openedAt) < b.cooldown
+	b.mu.Unlock()
+	if open {
+		return 0, fmt.Errorf("price source circuit open, retrying after %s", b.cooldown)
+	}
+
+	price, err := b.underlying.Price(ctx, currencyUnit, fiatCurrency)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err != nil {
+		b.consecutiveFails++
+		if b.consecutiveFails >= b.threshold {
+			b.openedAt = time.Now()
+		}
+		return 0, err
+	}
+	b.consecutiveFails = 0
+	return price, nil
+}
@@ -0,0 +1,83 @@
+package price
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// countingSource returns failUntilCall-many errors before it starts
+// succeeding, so tests can drive a CircuitBreakerSource through a known
+// sequence of underlying results.
+type countingSource struct {
+	calls         int
+	failUntilCall int
+}
+
+func (s *countingSource) Price(ctx context.Context, currencyUnit, fiatCurrency string) (float64, error) {
+	s.calls++
+	if s.calls <= s.failUntilCall {
+		return 0, errors.New("upstream unavailable")
+	}
+	return 42, nil
+}
+
+func TestCircuitBreakerSource_OpensAfterThreshold(t *testing.T) {
+	underlying := &countingSource{failUntilCall: 100}
+	breaker := WithCircuitBreaker(underlying, 3, time.Minute)
+
+	for i := 0; i < 3; i++ {
+		if _, err := breaker.Price(context.Background(), "ETH", "USD"); err == nil {
+			t.Fatalf("call %d: expected underlying failure, got nil error", i+1)
+		}
+	}
+	if underlying.calls != 3 {
+		t.Fatalf("underlying.calls = %d, want 3 (breaker should not yet be open)", underlying.calls)
+	}
+
+	if _, err := breaker.Price(context.Background(), "ETH", "USD"); err == nil {
+		t.Fatal("expected circuit-open error on the call past the threshold")
+	}
+	if underlying.calls != 3 {
+		t.Fatalf("underlying.calls = %d, want 3 (open circuit should skip the underlying call)", underlying.calls)
+	}
+}
+
+func TestCircuitBreakerSource_ClosesAfterCooldown(t *testing.T) {
+	underlying := &countingSource{failUntilCall: 2}
+	breaker := WithCircuitBreaker(underlying, 2, 20*time.Millisecond)
+
+	breaker.Price(context.Background(), "ETH", "USD")
+	breaker.Price(context.Background(), "ETH", "USD")
+
+	if _, err := breaker.Price(context.Background(), "ETH", "USD"); err == nil {
+		t.Fatal("expected circuit-open error during cooldown")
+	}
+
+	time.Sleep(30 * time.Millisecond)
+
+	price, err := breaker.Price(context.Background(), "ETH", "USD")
+	if err != nil {
+		t.Fatalf("Price() after cooldown = %v, want the underlying's now-successful result", err)
+	}
+	if price != 42 {
+		t.Fatalf("Price() = %v, want 42", price)
+	}
+}
+
+func TestCircuitBreakerSource_SuccessResetsFailureCount(t *testing.T) {
+	underlying := &countingSource{failUntilCall: 1}
+	breaker := WithCircuitBreaker(underlying, 2, time.Minute)
+
+	breaker.Price(context.Background(), "ETH", "USD") // fails, consecutiveFails = 1
+	breaker.Price(context.Background(), "ETH", "USD") // succeeds, consecutiveFails resets to 0
+
+	underlying.failUntilCall = 4 // calls 3 and 4 both fail
+	if _, err := breaker.Price(context.Background(), "ETH", "USD"); err == nil {
+		t.Fatal("expected the underlying failure to surface")
+	}
+	if _, err := breaker.Price(context.Background(), "ETH", "USD"); err == nil {
+		t.Fatal("expected a second consecutive failure to surface, not a stale open circuit")
+	}
+}
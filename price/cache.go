@@ -0,0 +1,58 @@
+package price
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type cacheKey struct {
+	currencyUnit string
+	fiatCurrency string
+}
+
+type cacheEntry struct {
+	price     float64
+	fetchedAt time.Time
+}
+
+// CachingSource wraps a Source and serves prices from memory until they're
+// older than ttl, so a price check on every wallet tick doesn't hammer the
+// upstream API.
+type CachingSource struct {
+	underlying Source
+	ttl        time.Duration
+
+	mu      sync.Mutex
+	entries map[cacheKey]cacheEntry
+}
+
+// WithCache wraps source with a TTL cache.
+func WithCache(source Source, ttl time.Duration) *CachingSource {
+	return &CachingSource{
+		underlying: source,
+		ttl:        ttl,
+		entries:    map[cacheKey]cacheEntry{},
+	}
+}
+
+func (c *CachingSource) Price(ctx context.Context, currencyUnit, fiatCurrency string) (float64, error) {
+	key := cacheKey{currencyUnit: currencyUnit, fiatCurrency: fiatCurrency}
+
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	c.mu.Unlock()
+	if ok && time.Since(entry.fetchedAt) < c.ttl {
+		return entry.price, nil
+	}
+
+	price, err := c.underlying.Price(ctx, currencyUnit, fiatCurrency)
+	if err != nil {
+		return 0, err
+	}
+
+	c.mu.Lock()
+	c.entries[key] = cacheEntry{price: price, fetchedAt: time.Now()}
+	c.mu.Unlock()
+	return price, nil
+}
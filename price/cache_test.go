@@ -0,0 +1,70 @@
+package price
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// callCountingSource returns an incrementing price and counts how many
+// times it was actually invoked, so tests can tell whether CachingSource
+// served a request from cache.
+type callCountingSource struct {
+	calls int
+}
+
+func (s *callCountingSource) Price(ctx context.Context, currencyUnit, fiatCurrency string) (float64, error) {
+	s.calls++
+	return float64(s.calls), nil
+}
+
+func TestCachingSource_ServesWithinTTLFromCache(t *testing.T) {
+	underlying := &callCountingSource{}
+	cached := WithCache(underlying, time.Minute)
+
+	first, err := cached.Price(context.Background(), "ETH", "USD")
+	if err != nil {
+		t.Fatalf("first Price() error: %v", err)
+	}
+	second, err := cached.Price(context.Background(), "ETH", "USD")
+	if err != nil {
+		t.Fatalf("second Price() error: %v", err)
+	}
+
+	if first != second {
+		t.Fatalf("Price() = %v then %v, want the same cached value", first, second)
+	}
+	if underlying.calls != 1 {
+		t.Fatalf("underlying.calls = %d, want 1 (second call should be served from cache)", underlying.calls)
+	}
+}
+
+func TestCachingSource_RefetchesAfterTTL(t *testing.T) {
+	underlying := &callCountingSource{}
+	cached := WithCache(underlying, 10*time.Millisecond)
+
+	if _, err := cached.Price(context.Background(), "ETH", "USD"); err != nil {
+		t.Fatalf("first Price() error: %v", err)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+
+	if _, err := cached.Price(context.Background(), "ETH", "USD"); err != nil {
+		t.Fatalf("second Price() error: %v", err)
+	}
+	if underlying.calls != 2 {
+		t.Fatalf("underlying.calls = %d, want 2 (entry should have expired)", underlying.calls)
+	}
+}
+
+func TestCachingSource_KeysByCurrencyPair(t *testing.T) {
+	underlying := &callCountingSource{}
+	cached := WithCache(underlying, time.Minute)
+
+	cached.Price(context.Background(), "ETH", "USD")
+	cached.Price(context.Background(), "ATOM", "USD")
+
+	if underlying.calls != 2 {
+		t.Fatalf("underlying.calls = %d, want 2 (distinct currency pairs shouldn't share a cache entry)", underlying.calls)
+	}
+}
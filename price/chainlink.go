@@ -0,0 +1,99 @@
+package price
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+)
+
+// latestRoundDataSelector is the 4-byte selector for
+// AggregatorV3Interface.latestRoundData().
+const latestRoundDataSelector = "0xfeaf968c"
+
+// chainlinkFeeds maps "CURRENCY/FIAT" to the Ethereum mainnet address of
+// the corresponding Chainlink price feed.
+var chainlinkFeeds = map[string]string{
+	"ETH/USD":  "0x5f4eC3Df9cbd43714FE2740f5E3616155c5b8419",
+	"BTC/USD":  "0xF4030086522a5bEEa4988F8cA5B36dbC97BeE88c",
+	"ATOM/USD": "0xDC4BDB458C6361093069Ca2aD30D74cc152EdC75",
+}
+
+// ChainlinkSource reads price feeds directly from an on-chain Chainlink
+// AggregatorV3Interface contract via eth_call, rather than going through a
+// centralized price API.
+type ChainlinkSource struct {
+	client   *http.Client
+	endpoint string
+}
+
+// NewChainlinkSource builds a ChainlinkSource that reads feeds from the
+// public Ethereum mainnet RPC at https://eth.llamarpc.com.
+func NewChainlinkSource() *ChainlinkSource {
+	return &ChainlinkSource{client: http.DefaultClient, endpoint: "https://eth.llamarpc.com"}
+}
+
+func (s *ChainlinkSource) Price(ctx context.Context, currencyUnit, fiatCurrency string) (float64, error) {
+	feed, ok := chainlinkFeeds[strings.ToUpper(currencyUnit)+"/"+strings.ToUpper(fiatCurrency)]
+	if !ok {
+		return 0, fmt.Errorf("no Chainlink feed configured for %s/%s", currencyUnit, fiatCurrency)
+	}
+
+	payload := map[string]interface{}{
+		"jsonrpc": "2.0",
+		"method":  "eth_call",
+		"params": []interface{}{
+			map[string]string{"to": feed, "data": latestRoundDataSelector},
+			"latest",
+		},
+		"id": 1,
+	}
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, bytes.NewBuffer(payloadBytes))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("calling Chainlink feed %s: %w", feed, err)
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Result string `json:"result"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return 0, fmt.Errorf("decoding eth_call response: %w", err)
+	}
+
+	return decodeLatestRoundDataAnswer(result.Result)
+}
+
+// decodeLatestRoundDataAnswer pulls the `answer` (int256, the second of
+// five 32-byte return words) out of latestRoundData()'s ABI-encoded
+// result and scales it down from the feed's 8 decimals.
+func decodeLatestRoundDataAnswer(hexResult string) (float64, error) {
+	hexResult = strings.TrimPrefix(hexResult, "0x")
+	if len(hexResult) < 128 {
+		return 0, fmt.Errorf("short latestRoundData result: %d hex chars", len(hexResult))
+	}
+
+	answerWord := hexResult[64:128]
+	answer := new(big.Int)
+	if _, ok := answer.SetString(answerWord, 16); !ok {
+		return 0, fmt.Errorf("could not parse answer word %q", answerWord)
+	}
+
+	scaled := new(big.Float).Quo(new(big.Float).SetInt(answer), big.NewFloat(1e8))
+	price, _ := scaled.Float64()
+	return price, nil
+}
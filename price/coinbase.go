@@ -0,0 +1,47 @@
+package price
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// CoinbaseSource queries Coinbase's public spot price endpoint.
+type CoinbaseSource struct {
+	client *http.Client
+}
+
+func NewCoinbaseSource() *CoinbaseSource {
+	return &CoinbaseSource{client: http.DefaultClient}
+}
+
+func (s *CoinbaseSource) Price(ctx context.Context, currencyUnit, fiatCurrency string) (float64, error) {
+	url := fmt.Sprintf("https://api.coinbase.com/v2/prices/%s-%s/spot", currencyUnit, fiatCurrency)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("querying Coinbase: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Data struct {
+			Amount string `json:"amount"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return 0, fmt.Errorf("decoding Coinbase response: %w", err)
+	}
+
+	price, err := strconv.ParseFloat(result.Data.Amount, 64)
+	if err != nil {
+		return 0, fmt.Errorf("parsing Coinbase price %q: %w", result.Data.Amount, err)
+	}
+	return price, nil
+}
@@ -0,0 +1,67 @@
+package price
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// CoinGeckoSource queries the public CoinGecko simple-price API.
+type CoinGeckoSource struct {
+	client *http.Client
+}
+
+func NewCoinGeckoSource() *CoinGeckoSource {
+	return &CoinGeckoSource{client: http.DefaultClient}
+}
+
+func (s *CoinGeckoSource) Price(ctx context.Context, currencyUnit, fiatCurrency string) (float64, error) {
+	coinID := coinGeckoID(currencyUnit)
+	vsCurrency := strings.ToLower(fiatCurrency)
+	url := fmt.Sprintf("https://api.coingecko.com/api/v3/simple/price?ids=%s&vs_currencies=%s", coinID, vsCurrency)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("querying CoinGecko: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result map[string]map[string]float64
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return 0, fmt.Errorf("decoding CoinGecko response: %w", err)
+	}
+
+	price, ok := result[coinID][vsCurrency]
+	if !ok {
+		return 0, fmt.Errorf("no CoinGecko price for %s in %s", currencyUnit, fiatCurrency)
+	}
+	return price, nil
+}
+
+// coinGeckoID maps a chain's currency unit symbol to its CoinGecko coin
+// id. CoinGecko has no generic symbol lookup endpoint, so common units are
+// mapped explicitly; anything else is passed through lowercased.
+func coinGeckoID(currencyUnit string) string {
+	switch strings.ToUpper(currencyUnit) {
+	case "ETH":
+		return "ethereum"
+	case "ATOM":
+		return "cosmos"
+	case "BTC":
+		return "bitcoin"
+	case "SOL":
+		return "solana"
+	case "DOT":
+		return "polkadot"
+	case "FIL":
+		return "filecoin"
+	default:
+		return strings.ToLower(currencyUnit)
+	}
+}
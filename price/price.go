@@ -0,0 +1,32 @@
+// Package price provides live fiat price lookups for a chain's native
+// currency unit, so wallet thresholds can be expressed in USD (or another
+// fiat currency) in addition to native units.
+package price
+
+import (
+	"context"
+	"fmt"
+)
+
+// Source returns the current price of one unit of currencyUnit (e.g.
+// "ETH", "ATOM") denominated in fiatCurrency (e.g. "USD").
+type Source interface {
+	Price(ctx context.Context, currencyUnit, fiatCurrency string) (float64, error)
+}
+
+// Build constructs the Source named by sourceName, matching the
+// `priceSource` field on a chain's config.
+func Build(sourceName string) (Source, error) {
+	switch sourceName {
+	case "coingecko":
+		return NewCoinGeckoSource(), nil
+	case "coinbase":
+		return NewCoinbaseSource(), nil
+	case "binance":
+		return NewBinanceSource(), nil
+	case "chainlink":
+		return NewChainlinkSource(), nil
+	default:
+		return nil, fmt.Errorf("unsupported price source: %q", sourceName)
+	}
+}